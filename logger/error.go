@@ -0,0 +1,63 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"fmt"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// stackTracer is satisfied by errors carrying a stack trace, e.g. those produced by
+// github.com/pkg/errors.
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// errorFields turns err into the fields WithError attaches to a log entry: the
+// conventional "error" field plus, whenever a stack trace can be found anywhere in
+// err's chain - including an errors.Join tree - an "error.stack" field with file:line
+// frames rather than just the message.
+func errorFields(err error) map[string]interface{} {
+	fields := map[string]interface{}{"error": err.Error()}
+
+	if stack := findStackTrace(err); stack != "" {
+		fields["error.stack"] = stack
+	}
+
+	return fields
+}
+
+// findStackTrace walks err's chain - following errors.Unwrap and the multi-error tree
+// produced by errors.Join - looking for the first StackTrace it can find.
+func findStackTrace(err error) string {
+	if st, ok := err.(stackTracer); ok {
+		return fmt.Sprintf("%+v", st.StackTrace())
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return findStackTrace(x.Unwrap())
+
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range x.Unwrap() {
+			if stack := findStackTrace(wrapped); stack != "" {
+				return stack
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,62 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestFindStackTrace_Direct(t *testing.T) {
+	if stack := findStackTrace(pkgerrors.New("boom")); stack == "" {
+		t.Fatal("expected a stack trace for a github.com/pkg/errors error")
+	}
+}
+
+func TestFindStackTrace_SingleUnwrap(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", pkgerrors.New("boom"))
+
+	if stack := findStackTrace(err); stack == "" {
+		t.Fatal("expected findStackTrace to see through a single Unwrap() error wrapper")
+	}
+}
+
+func TestFindStackTrace_JoinTree(t *testing.T) {
+	err := errors.Join(errors.New("plain"), pkgerrors.New("boom"))
+
+	if stack := findStackTrace(err); stack == "" {
+		t.Fatal("expected findStackTrace to walk an errors.Join tree")
+	}
+}
+
+func TestFindStackTrace_NoStack(t *testing.T) {
+	if stack := findStackTrace(errors.New("plain")); stack != "" {
+		t.Errorf("expected no stack trace for a plain error, got %q", stack)
+	}
+}
+
+func TestWithError_NilIsNoop(t *testing.T) {
+	if got := newLogrusLogger(Config{}, io.Discard).WithError(nil); got == nil {
+		t.Error("logrus backend: WithError(nil) must not return nil")
+	}
+
+	if got := newZapLogger(Config{}, io.Discard).WithError(nil); got == nil {
+		t.Error("zap backend: WithError(nil) must not return nil")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReopenableFile_ReopenPicksUpRotatedPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cloudinfo.log")
+
+	rf, err := newReopenableFile(path)
+	if err != nil {
+		t.Fatalf("newReopenableFile() error = %v", err)
+	}
+
+	if _, err := rf.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// simulate logrotate: move the file out from under the open descriptor
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("os.Rename() error = %v", err)
+	}
+
+	if err := rf.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+
+	if _, err := rf.Write([]byte("after rotation\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	if string(content) != "after rotation\n" {
+		t.Errorf("expected the reopened file to only contain post-rotation writes, got %q", string(content))
+	}
+}
+
+func TestInitLoggerWithConfig_ReopenPicksUpRotatedPath(t *testing.T) {
+	origRoot := root
+	reopenMu.Lock()
+	origChan, origSig := reopenChan, reopenSig
+	reopenMu.Unlock()
+
+	defer func() {
+		root = origRoot
+
+		reopenMu.Lock()
+		if reopenChan != nil && reopenChan != origChan {
+			signal.Stop(reopenChan)
+			close(reopenChan)
+		}
+		reopenChan, reopenSig = origChan, origSig
+		reopenMu.Unlock()
+	}()
+
+	path := filepath.Join(t.TempDir(), "cloudinfo.log")
+
+	InitLoggerWithConfig(Config{Output: path})
+	defer InitLoggerWithConfig(Config{}) // put the global output sink back to stdout
+
+	root.Info("before rotation")
+
+	// simulate logrotate: move the file out from under the open descriptor
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("os.Rename() error = %v", err)
+	}
+
+	if err := Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+
+	root.Info("after rotation")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	if strings.Contains(string(content), "before rotation") || !strings.Contains(string(content), "after rotation") {
+		t.Errorf("expected the reopened file to only contain the post-rotation line, got %q", string(content))
+	}
+}
+
+func TestInstallReopenSignal_ReusesRegistrationForSameSignal(t *testing.T) {
+	reopenMu.Lock()
+	prevChan, prevSig := reopenChan, reopenSig
+	reopenChan, reopenSig = nil, nil
+	reopenMu.Unlock()
+
+	defer func() {
+		reopenMu.Lock()
+		if reopenChan != nil {
+			signal.Stop(reopenChan)
+			close(reopenChan)
+		}
+		reopenChan, reopenSig = prevChan, prevSig
+		reopenMu.Unlock()
+	}()
+
+	installReopenSignal(nil)
+	reopenMu.Lock()
+	first := reopenChan
+	reopenMu.Unlock()
+
+	installReopenSignal(nil)
+	reopenMu.Lock()
+	second := reopenChan
+	reopenMu.Unlock()
+
+	if first != second {
+		t.Error("expected installReopenSignal to reuse the existing registration instead of installing a second one for the same signal")
+	}
+}
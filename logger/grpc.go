@@ -0,0 +1,101 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// correlationIDMetadataKey is the gRPC metadata key carrying the correlation id.
+const correlationIDMetadataKey = "x-correlation-id"
+
+// AddFields enriches the context logger with additional fields, analogous to
+// ctxlogrus.AddFields. It is the primitive CorrelationIDMiddleware, the gRPC
+// interceptors below, and handlers deep in the call stack use to enrich the
+// request-scoped logger without threading a logCtxBuilder through every function.
+func AddFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	return ToContext(ctx, fields)
+}
+
+// UnaryServerInterceptor pulls the correlation id out of the incoming gRPC metadata,
+// minting one if it is absent, and adds it to the request-scoped context logger.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withCorrelationID(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: withCorrelationID(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor forwards the correlation id carried by the outgoing context's
+// logger fields onto the gRPC metadata, so a single scrape can be traced end-to-end
+// across cloud provider SDK calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(forwardCorrelationID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// withCorrelationID extracts the correlation id from incoming gRPC metadata (or mints
+// one) and stores it in the context logger fields.
+func withCorrelationID(ctx context.Context) context.Context {
+	var cid string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(correlationIDMetadataKey); len(vals) > 0 {
+			cid = vals[0]
+		}
+	}
+	if cid == "" {
+		cid = uuid.New().String()
+	}
+
+	return AddFields(ctx, map[string]interface{}{correlationIdKey: cid})
+}
+
+// forwardCorrelationID propagates the correlation id already stored on the context
+// logger fields onto the outgoing gRPC metadata.
+func forwardCorrelationID(ctx context.Context) context.Context {
+	fields, ok := ctx.Value(ctxKey).(map[string]interface{})
+	if !ok {
+		return ctx
+	}
+
+	cid, ok := fields[correlationIdKey].(string)
+	if !ok || cid == "" {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, correlationIDMetadataKey, cid)
+}
+
+// contextServerStream overrides Context() on a grpc.ServerStream so that the server
+// handler observes the correlation-id enriched context.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,49 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"io"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLogger_WarningLevelSameAcrossBackends(t *testing.T) {
+	logrusLogger := newLogrusLogger(Config{Level: "warning"}, io.Discard).(*LogEntryWrapper)
+	if logrusLogger.Entry.Logger.Level.String() != "warning" {
+		t.Fatalf("expected logrus level %q, got %q", "warning", logrusLogger.Entry.Logger.Level.String())
+	}
+
+	zl := newZapLogger(Config{Level: "warning"}, io.Discard).(*zapLogger)
+	if !zl.Desugar().Core().Enabled(zapcore.WarnLevel) {
+		t.Error("expected zap backend to enable Warn level for Config.Level \"warning\"")
+	}
+	if zl.Desugar().Core().Enabled(zapcore.InfoLevel) {
+		t.Error("Config.Level \"warning\" must not silently fall back to Info under the zap backend")
+	}
+}
+
+func TestNewLogger_TraceLevelSameAcrossBackends(t *testing.T) {
+	logrusLogger := newLogrusLogger(Config{Level: "trace"}, io.Discard).(*LogEntryWrapper)
+	if logrusLogger.Entry.Logger.Level.String() != "debug" {
+		t.Fatalf("expected Config.Level \"trace\" to map to logrus debug level, got %q", logrusLogger.Entry.Logger.Level.String())
+	}
+
+	zl := newZapLogger(Config{Level: "trace"}, io.Discard).(*zapLogger)
+	if !zl.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		t.Error("expected Config.Level \"trace\" to enable Debug level under the zap backend, its closest equivalent")
+	}
+}
@@ -16,15 +16,17 @@ package logger
 
 import (
 	"context"
+	"os"
+	"strings"
+
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
 )
 
 type ctxMarker struct{}
 
 var (
 	ctxKey = &ctxMarker{}
-	logger = logrus.New() // default logger
+	root   = newLogger(Config{Level: "info", Format: "text"}) // default logger
 )
 
 const (
@@ -35,62 +37,139 @@ const (
 	providerKey = "provider"
 	serviceKey  = "service"
 	regionKey   = "region"
+
+	// ContextKey is the gin context key the correlation id is stored under.
+	ContextKey = "correlation-id"
+
+	// BackendLogrus selects the logrus based Logger implementation. This is the default.
+	BackendLogrus = "logrus"
+	// BackendZap selects the go.uber.org/zap based Logger implementation, meant for
+	// higher-throughput scraping paths where logrus becomes a bottleneck.
+	BackendZap = "zap"
 )
 
-// InitLogger sets level and format for Logger
+// InitLogger sets level and format for Logger, using the default (logrus) backend
 func InitLogger(level, format string) {
-
-	logger = newLogger(Config{
+	InitLoggerWithConfig(Config{
 		Level:  level,
 		Format: format,
 	})
+}
 
+// InitLoggerWithConfig is the Config based counterpart of InitLogger. It additionally
+// supports selecting a backend and a file output, re-opening the output's underlying
+// descriptor on SIGHUP (or config.ReopenSignal, if set) so external tools like
+// logrotate can rotate cloudinfo's log without dropping writes or requiring a process
+// restart.
+func InitLoggerWithConfig(config Config) {
+	root = newLogger(config)
+
+	if config.Output != "" && config.Output != "stdout" && config.Output != "stderr" {
+		installReopenSignal(config.ReopenSignal)
+	}
 }
 
 // Config holds information necessary for customizing the logger.
 type Config struct {
-	Level  string
-	Format string
+	Level   string
+	Format  string
+	Backend string
+
+	// Output is the log sink: "", "stdout" and "stderr" are special-cased, anything
+	// else is treated as a file path.
+	Output string
+	// ReopenSignal overrides the signal that triggers re-opening the Output file.
+	// Defaults to SIGHUP.
+	ReopenSignal os.Signal
 }
 
-func newLogger(config Config) *logrus.Logger {
-	logger := logrus.New()
-
-	level, err := logrus.ParseLevel(config.Level)
+// newLogger builds a Logger for the backend selected by Config.Backend, defaulting to
+// logrus, writing to the sink selected by Config.Output.
+func newLogger(config Config) Logger {
+	w, err := sinkWriter(config)
 	if err != nil {
-		level = logrus.InfoLevel
+		w = os.Stderr
 	}
 
-	logger.Level = level
-
-	switch config.Format {
-	case "json":
-		logger.Formatter = new(logrus.JSONFormatter)
-
+	switch config.Backend {
+	case BackendZap:
+		return newZapLogger(config, w)
 	default:
-		textFormatter := new(logrus.TextFormatter)
-		textFormatter.FullTimestamp = true
+		return newLogrusLogger(config, w)
+	}
+}
 
-		logger.Formatter = textFormatter
+// normalizeLevel canonicalizes level names that one backend accepts and another
+// doesn't, so Config.Level means the same verbosity no matter which Config.Backend is
+// selected:
+//   - logrus.ParseLevel accepts both "warn" and "warning"; zapcore.Level.UnmarshalText
+//     only accepts "warn".
+//   - logrus has a TraceLevel below Debug; zap has no trace level at all, so "trace" is
+//     mapped to zap's most verbose level, "debug" - this is a lossy mapping, the zap
+//     backend cannot distinguish Trace from Debug calls.
+func normalizeLevel(level string) string {
+	switch {
+	case strings.EqualFold(level, "warning"):
+		return "warn"
+	case strings.EqualFold(level, "trace"):
+		return "debug"
+	default:
+		return level
 	}
+}
+
+// Logger gathers all the log operations used in the application, mainly operations implemented by "conventional" loggers.
+// The interface is meant to decouple the application from any particular logging library, so that backends
+// (see Config.Backend) can be swapped without the change rippling through every call site.
+type Logger interface {
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Warn(args ...interface{})
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+
+	Debugln(args ...interface{})
+	Infoln(args ...interface{})
+	Warnln(args ...interface{})
+	Errorln(args ...interface{})
+	Fatalln(args ...interface{})
 
-	return logger
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+
+	WithField(key string, value interface{}) Logger
+	WithFields(fields map[string]interface{}) Logger
+	WithError(err error) Logger
 }
 
+// ContextLogger is the handle returned by Extract. It is a Logger that already carries
+// whatever fields were accumulated on the context it was extracted from.
+type ContextLogger = Logger
+
 // Extract assembles the entry with the fields extracted from the context
 func Extract(ctx context.Context) ContextLogger {
 
 	fds, ok := ctx.Value(ctxKey).(map[string]interface{})
-	if !ok || fds == nil {
-		return logrus.NewEntry(logger)
+
+	fields := make(map[string]interface{}, len(fds))
+	if ok {
+		for k, v := range fds {
+			fields[k] = v
+		}
 	}
 
-	fields := logrus.Fields{}
-	for k, v := range fds {
+	for k, v := range contextFieldValues(ctx) {
 		fields[k] = v
 	}
 
-	return logger.WithFields(fields)
+	if len(fields) == 0 {
+		return root
+	}
+
+	return root.WithFields(fields)
 }
 
 // ToContext adds
@@ -119,27 +198,6 @@ func GetCorrelationId(c *gin.Context) string {
 	return id
 }
 
-// LogEntryWrapper wraps the logger entry implementation
-// By embedding the library specific entry (logrus here), we have the default implementation "out of the box"
-type LogEntryWrapper struct {
-	// the default logging library is logrus
-	*logrus.Entry
-}
-
-// ContextLogger gathers all the log operations used in the application, mainly operations implemented by "conventional" loggers
-// The interface is meant to decouple application dependency on logger libraries
-type ContextLogger interface {
-	WithError(err error) *logrus.Entry
-	WithField(key string, value interface{}) *logrus.Entry
-	WithFields(fields logrus.Fields) *logrus.Entry
-	Debug(args ...interface{})
-	Info(args ...interface{})
-	Warn(args ...interface{})
-	Debugf(format string, args ...interface{})
-	Infof(format string, args ...interface{})
-	Fatal(args ...interface{})
-}
-
 // logCtxBuilder helper struct to build the context for logging purposes
 type logCtxBuilder struct {
 	ctx map[string]interface{}
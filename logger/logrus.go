@@ -0,0 +1,74 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogEntryWrapper wraps the logger entry implementation
+// By embedding the library specific entry (logrus here), we have the default implementation "out of the box"
+type LogEntryWrapper struct {
+	// the default logging library is logrus
+	*logrus.Entry
+}
+
+// newLogrusLogger builds the default, logrus backed Logger implementation, writing to w.
+func newLogrusLogger(config Config, w io.Writer) Logger {
+	l := logrus.New()
+	l.Out = w
+
+	level, err := logrus.ParseLevel(normalizeLevel(config.Level))
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+
+	l.Level = level
+
+	switch config.Format {
+	case "json":
+		l.Formatter = new(logrus.JSONFormatter)
+
+	default:
+		textFormatter := new(logrus.TextFormatter)
+		textFormatter.FullTimestamp = true
+
+		l.Formatter = textFormatter
+	}
+
+	return &LogEntryWrapper{Entry: logrus.NewEntry(l)}
+}
+
+// WithField adds an arbitrary value to the logrus entry with the provided key
+func (w *LogEntryWrapper) WithField(key string, value interface{}) Logger {
+	return &LogEntryWrapper{Entry: w.Entry.WithField(key, value)}
+}
+
+// WithFields adds arbitrary values to the logrus entry
+func (w *LogEntryWrapper) WithFields(fields map[string]interface{}) Logger {
+	return &LogEntryWrapper{Entry: w.Entry.WithFields(logrus.Fields(fields))}
+}
+
+// WithError adds the error to the logrus entry under the conventional "error" field,
+// plus an "error.stack" field whenever a stack trace can be found in err's chain.
+func (w *LogEntryWrapper) WithError(err error) Logger {
+	if err == nil {
+		return w
+	}
+
+	return &LogEntryWrapper{Entry: w.Entry.WithFields(logrus.Fields(errorFields(err)))}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// correlationIDHeader is the HTTP header carrying the correlation id, both on
+// incoming requests and on the echoed response.
+const correlationIDHeader = "X-Correlation-ID"
+
+// CorrelationIDMiddleware is a gin middleware that reads X-Correlation-ID from the
+// incoming request, minting a new one if it is absent, echoes it back on the
+// response, stores it in the gin context under ContextKey and adds it to the
+// request's context logger fields so that Extract(c.Request.Context()) emits a
+// "correlation-id" field automatically.
+func CorrelationIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cid := c.GetHeader(correlationIDHeader)
+		if cid == "" {
+			cid = uuid.New().String()
+		}
+
+		c.Set(ContextKey, cid)
+		c.Header(correlationIDHeader, cid)
+
+		ctx := AddFields(c.Request.Context(), map[string]interface{}{correlationIdKey: cid})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// CorrelationIDHandler wraps an http.Handler with the same correlation id behavior as
+// CorrelationIDMiddleware, for services that are not built on top of gin.
+func CorrelationIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cid := r.Header.Get(correlationIDHeader)
+		if cid == "" {
+			cid = uuid.New().String()
+		}
+
+		w.Header().Set(correlationIDHeader, cid)
+
+		ctx := AddFields(r.Context(), map[string]interface{}{correlationIdKey: cid})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// CorrelationIDRoundTripper forwards the current context's correlation id on outbound
+// HTTP calls - e.g. to cloud provider SDKs, which are all HTTP based - so a single
+// scrape can be traced end-to-end. Next defaults to http.DefaultTransport when nil.
+type CorrelationIDRoundTripper struct {
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt CorrelationIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := rt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if fields, ok := req.Context().Value(ctxKey).(map[string]interface{}); ok {
+		if cid, ok := fields[correlationIdKey].(string); ok && cid != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set(correlationIDHeader, cid)
+		}
+	}
+
+	return next.RoundTrip(req)
+}
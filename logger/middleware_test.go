@@ -0,0 +1,67 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCorrelationIDRoundTripper_ForwardsCorrelationID(t *testing.T) {
+	var gotHeader string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		gotHeader = req.Header.Get(correlationIDHeader)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	rt := CorrelationIDRoundTripper{Next: next}
+
+	ctx := AddFields(context.Background(), map[string]interface{}{correlationIdKey: "abc-123"})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if gotHeader != "abc-123" {
+		t.Errorf("expected %s header to be forwarded as %q, got %q", correlationIDHeader, "abc-123", gotHeader)
+	}
+}
+
+func TestCorrelationIDRoundTripper_NoCorrelationIDIsNoop(t *testing.T) {
+	var gotHeader string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		gotHeader = req.Header.Get(correlationIDHeader)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	rt := CorrelationIDRoundTripper{Next: next}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if gotHeader != "" {
+		t.Errorf("expected no %s header without a correlation id on the context, got %q", correlationIDHeader, gotHeader)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	contextFieldsMu sync.RWMutex
+	// contextFields holds the extractors registered through RegisterContextField,
+	// keyed by the field name they populate.
+	contextFields = map[string]func(context.Context) (interface{}, bool){}
+)
+
+func init() {
+	RegisterContextField("trace_id", traceIDField)
+	RegisterContextField("span_id", spanIDField)
+	RegisterContextField("trace_sampled", traceSampledField)
+}
+
+// RegisterContextField registers an extractor that derives an additional field from a
+// context.Context. Every ContextLogger built by Extract includes the field whenever the
+// extractor returns true, letting other packages - e.g. the scraper - plug in derived
+// fields (scrape depth, provider retry count) without modifying logCtxBuilder.
+func RegisterContextField(key string, extractor func(context.Context) (interface{}, bool)) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+
+	contextFields[key] = extractor
+}
+
+// contextFieldValues runs every registered extractor against ctx and collects the
+// fields that produced a value.
+func contextFieldValues(ctx context.Context) map[string]interface{} {
+	contextFieldsMu.RLock()
+	defer contextFieldsMu.RUnlock()
+
+	fields := make(map[string]interface{}, len(contextFields))
+	for key, extractor := range contextFields {
+		if value, ok := extractor(ctx); ok {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
+
+// traceIDField extracts the active OpenTelemetry span's trace id, mirroring how goma's
+// log package injects OpenCensus trace/span IDs.
+func traceIDField(ctx context.Context) (interface{}, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return nil, false
+	}
+
+	return sc.TraceID().String(), true
+}
+
+// spanIDField extracts the active OpenTelemetry span's span id.
+func spanIDField(ctx context.Context) (interface{}, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasSpanID() {
+		return nil, false
+	}
+
+	return sc.SpanID().String(), true
+}
+
+// traceSampledField reports whether the active OpenTelemetry span was sampled.
+func traceSampledField(ctx context.Context) (interface{}, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return nil, false
+	}
+
+	return sc.IsSampled(), true
+}
@@ -0,0 +1,53 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestExtract_InjectsOTelFields(t *testing.T) {
+	orig := root
+	defer func() { root = orig }()
+
+	buf := &bytes.Buffer{}
+	l := logrus.New()
+	l.Out = buf
+	l.Formatter = new(logrus.JSONFormatter)
+	root = &LogEntryWrapper{Entry: logrus.NewEntry(l)}
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("logger_test").Start(context.Background(), "op")
+	defer span.End()
+
+	Extract(ctx).Info("hello")
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+
+	for _, key := range []string{"trace_id", "span_id", "trace_sampled"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("expected field %q in log output, got %v", key, fields)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// sampledLogger wraps a Logger and drops all but every Nth Debug/Info call observed
+// at a given call site. counts is shared across every Logger derived from it (via
+// WithField, WithFields, WithError) so a call site samples consistently no matter
+// which derived logger it is reached through.
+type sampledLogger struct {
+	Logger
+	every  uint64
+	counts *sync.Map // map[string]*uint64
+}
+
+// Sampled wraps the package logger so that all but every Nth Debug/Info call made at
+// the same call site are dropped, letting periodic per-region scrapers log progress
+// without flooding when tens of thousands of instance types are enumerated. It is
+// safe for concurrent use by scrape goroutines.
+func Sampled(every int) ContextLogger {
+	if every < 1 {
+		every = 1
+	}
+
+	return &sampledLogger{Logger: root, every: uint64(every), counts: &sync.Map{}}
+}
+
+// allow reports whether the call site at the given stack depth should log, advancing
+// that site's counter regardless of the outcome.
+func (s *sampledLogger) allow(skip int) bool {
+	site := "unknown"
+	if _, file, line, ok := runtime.Caller(skip); ok {
+		site = file + ":" + strconv.Itoa(line)
+	}
+
+	v, _ := s.counts.LoadOrStore(site, new(uint64))
+	n := atomic.AddUint64(v.(*uint64), 1)
+
+	return (n-1)%s.every == 0
+}
+
+func (s *sampledLogger) WithField(key string, value interface{}) Logger {
+	return &sampledLogger{Logger: s.Logger.WithField(key, value), every: s.every, counts: s.counts}
+}
+
+func (s *sampledLogger) WithFields(fields map[string]interface{}) Logger {
+	return &sampledLogger{Logger: s.Logger.WithFields(fields), every: s.every, counts: s.counts}
+}
+
+func (s *sampledLogger) WithError(err error) Logger {
+	return &sampledLogger{Logger: s.Logger.WithError(err), every: s.every, counts: s.counts}
+}
+
+func (s *sampledLogger) Debug(args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Debug(args...)
+	}
+}
+
+func (s *sampledLogger) Debugln(args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Debugln(args...)
+	}
+}
+
+func (s *sampledLogger) Debugf(format string, args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Debugf(format, args...)
+	}
+}
+
+func (s *sampledLogger) Info(args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Info(args...)
+	}
+}
+
+func (s *sampledLogger) Infoln(args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Infoln(args...)
+	}
+}
+
+func (s *sampledLogger) Infof(format string, args ...interface{}) {
+	if s.allow(2) {
+		s.Logger.Infof(format, args...)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSampled_ConcurrentInfoKeepsExactlyEveryNth(t *testing.T) {
+	orig := root
+	defer func() { root = orig }()
+
+	buf := &bytes.Buffer{}
+	l := logrus.New()
+	l.Out = buf
+	l.Formatter = new(logrus.JSONFormatter)
+	root = &LogEntryWrapper{Entry: logrus.NewEntry(l)}
+
+	const (
+		goroutines        = 50
+		callsPerGoroutine = 20
+		every             = 5
+	)
+
+	sampled := Sampled(every)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < callsPerGoroutine; j++ {
+				sampled.Info("progress") // single call site, exercised concurrently
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := strings.Count(buf.String(), "\n")
+	want := (goroutines * callsPerGoroutine) / every
+	if got != want {
+		t.Errorf("expected exactly %d sampled lines, got %d", want, got)
+	}
+}
+
+func TestSampled_DropsAllButEveryNthAtCallSite(t *testing.T) {
+	orig := root
+	defer func() { root = orig }()
+
+	buf := &bytes.Buffer{}
+	l := logrus.New()
+	l.Out = buf
+	l.Formatter = new(logrus.JSONFormatter)
+	root = &LogEntryWrapper{Entry: logrus.NewEntry(l)}
+
+	sampled := Sampled(3)
+	for i := 0; i < 9; i++ {
+		sampled.Info("progress")
+	}
+
+	if got, want := strings.Count(buf.String(), "\n"), 3; got != want {
+		t.Errorf("expected %d sampled lines out of 9 calls, got %d", want, got)
+	}
+}
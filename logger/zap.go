@@ -0,0 +1,85 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"io"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapLogger adapts a *zap.SugaredLogger to the Logger interface. It is the backend of
+// choice for higher-throughput scraping paths, where logrus's reflection based
+// formatting starts to show up in profiles.
+type zapLogger struct {
+	*zap.SugaredLogger
+}
+
+// newZapLogger builds the zap backed Logger implementation, writing to w.
+func newZapLogger(config Config, w io.Writer) Logger {
+	level := zapcore.InfoLevel
+	if err := level.UnmarshalText([]byte(normalizeLevel(config.Level))); err != nil {
+		level = zapcore.InfoLevel
+	}
+
+	var encoder zapcore.Encoder
+	if config.Format == "json" {
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	} else {
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.AddSync(w), level)
+
+	return &zapLogger{SugaredLogger: zap.New(core).Sugar()}
+}
+
+// WithField adds an arbitrary value to the zap logger with the provided key
+func (z *zapLogger) WithField(key string, value interface{}) Logger {
+	return &zapLogger{SugaredLogger: z.SugaredLogger.With(key, value)}
+}
+
+// WithFields adds arbitrary values to the zap logger
+func (z *zapLogger) WithFields(fields map[string]interface{}) Logger {
+	args := make([]interface{}, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+
+	return &zapLogger{SugaredLogger: z.SugaredLogger.With(args...)}
+}
+
+// WithError adds the error to the zap logger under the conventional "error" field,
+// plus an "error.stack" field whenever a stack trace can be found in err's chain.
+func (z *zapLogger) WithError(err error) Logger {
+	if err == nil {
+		return z
+	}
+
+	fields := errorFields(err)
+	args := make([]interface{}, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+
+	return &zapLogger{SugaredLogger: z.SugaredLogger.With(args...)}
+}
+
+func (z *zapLogger) Debugln(args ...interface{}) { z.SugaredLogger.Debug(args...) }
+func (z *zapLogger) Infoln(args ...interface{})  { z.SugaredLogger.Info(args...) }
+func (z *zapLogger) Warnln(args ...interface{})  { z.SugaredLogger.Warn(args...) }
+func (z *zapLogger) Errorln(args ...interface{}) { z.SugaredLogger.Error(args...) }
+func (z *zapLogger) Fatalln(args ...interface{}) { z.SugaredLogger.Fatal(args...) }